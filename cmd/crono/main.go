@@ -8,8 +8,11 @@ import (
 	"os"
 	"time"
 
+	"github.com/example/crono/internal/api"
+	"github.com/example/crono/internal/clock"
 	"github.com/example/crono/internal/dsl"
 	"github.com/example/crono/internal/scheduler"
+	"github.com/example/crono/internal/store"
 )
 
 func main() {
@@ -29,6 +32,10 @@ func main() {
 		nextCmd(os.Args[2:])
 	case "explain":
 		explainCmd(os.Args[2:])
+	case "pause":
+		pauseCmd(os.Args[2:])
+	case "resume":
+		resumeCmd(os.Args[2:])
 	default:
 		usage()
 	}
@@ -37,16 +44,20 @@ func main() {
 func usage() {
 	fmt.Println(`crono - mini scheduler DSL
 Usage:
-  crono run <file.crn>           # start the scheduler and execute jobs
+  crono run [--state path.json] [--listen :8080] <file.crn>   # start the scheduler and execute jobs
   crono validate <file.crn>      # validate syntax
   crono next <file.crn> -n 5     # display next occurrences
   crono explain <file.crn>       # explain each job in text
+  crono pause --state path.json <job>   # pause a job until resumed
+  crono resume --state path.json <job>  # resume a paused job
 `)
 }
 
 func runCmd(args []string) {
 	fs := flag.NewFlagSet("run", flag.ExitOnError)
 	file := fs.String("f", "", ".crn file (alias: positional arg)")
+	statePath := fs.String("state", "", "path to a JSON state file (enables persistence and missed-run catch-up)")
+	listen := fs.String("listen", "", "address to serve the control/observability API on (e.g. :8080); disabled if empty")
 	fs.Parse(args)
 	var path string
 	if *file != "" {
@@ -62,7 +73,28 @@ func runCmd(args []string) {
 		log.Fatalf("Parse: %v", err)
 	}
 	ctx := context.Background()
-	engine := scheduler.NewEngine(prog)
+
+	var engine *scheduler.Engine
+	if *statePath != "" {
+		st, err := store.NewJSONStore(*statePath)
+		if err != nil {
+			log.Fatalf("State: %v", err)
+		}
+		engine = scheduler.NewEngineWithStore(prog, st)
+	} else {
+		engine = scheduler.NewEngine(prog)
+	}
+
+	if *listen != "" {
+		srv := api.NewServer(engine)
+		go func() {
+			log.Printf("API: listening on %s", *listen)
+			if err := srv.ListenAndServe(*listen); err != nil {
+				log.Fatalf("API: %v", err)
+			}
+		}()
+	}
+
 	log.Printf("Starting scheduler (%d job[s])", len(prog.Jobs))
 	if err := engine.Run(ctx); err != nil {
 		log.Fatalf("Scheduler: %v", err)
@@ -77,6 +109,11 @@ func validateCmd(args []string) {
 	if err != nil {
 		log.Fatalf("Invalid: %v", err)
 	}
+	for _, j := range prog.Jobs {
+		if _, err := scheduler.NextRun(j.Schedule, clock.Real{}); err != nil {
+			log.Fatalf("Invalid: job %q: schedule %q: %v", j.Name, j.Schedule, err)
+		}
+	}
 	fmt.Printf("OK: %d job(s)\n", len(prog.Jobs))
 }
 
@@ -104,7 +141,7 @@ func nextCmd(args []string) {
 		fmt.Printf("Job %q:\n", j.Name)
 		t := start
 		for i := 0; i < *n; i++ {
-			next, err := scheduler.NextRun(j.Schedule, t)
+			next, err := scheduler.NextRun(j.Schedule, clock.Fixed{At: t})
 			if err != nil {
 				fmt.Printf("  error: %v\n", err)
 				break
@@ -115,6 +152,42 @@ func nextCmd(args []string) {
 	}
 }
 
+func pauseCmd(args []string) {
+	fs := flag.NewFlagSet("pause", flag.ExitOnError)
+	statePath := fs.String("state", "", "path to the JSON state file")
+	fs.Parse(args)
+	if *statePath == "" || fs.NArg() == 0 {
+		log.Fatal("usage: crono pause --state path.json <job>")
+	}
+	st, err := store.NewJSONStore(*statePath)
+	if err != nil {
+		log.Fatalf("State: %v", err)
+	}
+	name := fs.Arg(0)
+	if err := st.SetManualPause(name); err != nil {
+		log.Fatalf("Pause: %v", err)
+	}
+	fmt.Printf("paused %q\n", name)
+}
+
+func resumeCmd(args []string) {
+	fs := flag.NewFlagSet("resume", flag.ExitOnError)
+	statePath := fs.String("state", "", "path to the JSON state file")
+	fs.Parse(args)
+	if *statePath == "" || fs.NArg() == 0 {
+		log.Fatal("usage: crono resume --state path.json <job>")
+	}
+	st, err := store.NewJSONStore(*statePath)
+	if err != nil {
+		log.Fatalf("State: %v", err)
+	}
+	name := fs.Arg(0)
+	if err := st.ClearPause(name); err != nil {
+		log.Fatalf("Resume: %v", err)
+	}
+	fmt.Printf("resumed %q\n", name)
+}
+
 func explainCmd(args []string) {
 	if len(args) == 0 {
 		log.Fatal("specify a .crn file")
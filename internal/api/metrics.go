@@ -0,0 +1,160 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/example/crono/internal/scheduler"
+)
+
+// durBuckets are the histogram bucket upper bounds (seconds) for
+// crono_job_duration_seconds, chosen to span a sub-second script up to a
+// five-minute batch job.
+var durBuckets = []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 300}
+
+type runKey struct {
+	job    string
+	status string
+}
+
+// collector consumes an engine's event stream and keeps just enough state
+// in memory to render a Prometheus scrape; run counts, durations and
+// in-flight gauges come from events only. At scrape time it reads the
+// engine's job list and, through NextFire, each job's next fire time.
+type collector struct {
+	engine *scheduler.Engine
+
+	mu        sync.Mutex
+	runsTotal map[runKey]int64
+	durSum    map[string]float64
+	durCount  map[string]int64
+	durBucket map[string][]int64 // cumulative counts, parallel to durBuckets
+	inFlight  map[string]int
+}
+
+func newCollector(engine *scheduler.Engine) *collector {
+	return &collector{
+		engine:    engine,
+		runsTotal: map[runKey]int64{},
+		durSum:    map[string]float64{},
+		durCount:  map[string]int64{},
+		durBucket: map[string][]int64{},
+		inFlight:  map[string]int{},
+	}
+}
+
+func (c *collector) collect(events <-chan scheduler.Event) {
+	for ev := range events {
+		switch ev.Type {
+		case scheduler.EventStarted:
+			c.mu.Lock()
+			c.inFlight[ev.Job]++
+			c.mu.Unlock()
+		case scheduler.EventFinished:
+			c.mu.Lock()
+			if c.inFlight[ev.Job] > 0 {
+				c.inFlight[ev.Job]--
+			}
+			c.runsTotal[runKey{job: ev.Job, status: string(ev.Status)}]++
+			secs := ev.Duration.Seconds()
+			c.durSum[ev.Job] += secs
+			c.durCount[ev.Job]++
+			buckets := c.durBucket[ev.Job]
+			if buckets == nil {
+				buckets = make([]int64, len(durBuckets))
+			}
+			for i, ub := range durBuckets {
+				if secs <= ub {
+					buckets[i]++
+				}
+			}
+			c.durBucket[ev.Job] = buckets
+			c.mu.Unlock()
+		}
+	}
+}
+
+// handle renders the current state in Prometheus text exposition format.
+func (c *collector) handle(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	runsTotal := make(map[runKey]int64, len(c.runsTotal))
+	for k, v := range c.runsTotal {
+		runsTotal[k] = v
+	}
+	durSum := make(map[string]float64, len(c.durSum))
+	for k, v := range c.durSum {
+		durSum[k] = v
+	}
+	durCount := make(map[string]int64, len(c.durCount))
+	for k, v := range c.durCount {
+		durCount[k] = v
+	}
+	durBucket := make(map[string][]int64, len(c.durBucket))
+	for k, v := range c.durBucket {
+		durBucket[k] = append([]int64(nil), v...)
+	}
+	inFlight := make(map[string]int, len(c.inFlight))
+	for k, v := range c.inFlight {
+		inFlight[k] = v
+	}
+	c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP crono_job_runs_total Total number of job runs by outcome.")
+	fmt.Fprintln(w, "# TYPE crono_job_runs_total counter")
+	for _, k := range sortedRunKeys(runsTotal) {
+		fmt.Fprintf(w, "crono_job_runs_total{job=%q,status=%q} %d\n", k.job, k.status, runsTotal[k])
+	}
+
+	fmt.Fprintln(w, "# HELP crono_job_duration_seconds Duration of job runs in seconds.")
+	fmt.Fprintln(w, "# TYPE crono_job_duration_seconds histogram")
+	for _, job := range sortedKeys(durCount) {
+		buckets := durBucket[job]
+		for i, ub := range durBuckets {
+			fmt.Fprintf(w, "crono_job_duration_seconds_bucket{job=%q,le=\"%g\"} %d\n", job, ub, buckets[i])
+		}
+		fmt.Fprintf(w, "crono_job_duration_seconds_bucket{job=%q,le=\"+Inf\"} %d\n", job, durCount[job])
+		fmt.Fprintf(w, "crono_job_duration_seconds_sum{job=%q} %g\n", job, durSum[job])
+		fmt.Fprintf(w, "crono_job_duration_seconds_count{job=%q} %d\n", job, durCount[job])
+	}
+
+	fmt.Fprintln(w, "# HELP crono_job_in_flight Number of currently running invocations.")
+	fmt.Fprintln(w, "# TYPE crono_job_in_flight gauge")
+	for _, j := range c.engine.Jobs() {
+		fmt.Fprintf(w, "crono_job_in_flight{job=%q} %d\n", j.Name, inFlight[j.Name])
+	}
+
+	fmt.Fprintln(w, "# HELP crono_job_next_fire_timestamp Unix timestamp of the job's next scheduled fire.")
+	fmt.Fprintln(w, "# TYPE crono_job_next_fire_timestamp gauge")
+	for _, j := range c.engine.Jobs() {
+		if next, ok := c.engine.NextFire(j.Name); ok {
+			fmt.Fprintf(w, "crono_job_next_fire_timestamp{job=%q} %d\n", j.Name, next.Unix())
+		}
+	}
+}
+
+func sortedKeys(m map[string]int64) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedRunKeys(m map[runKey]int64) []runKey {
+	out := make([]runKey, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].job != out[j].job {
+			return out[i].job < out[j].job
+		}
+		return out[i].status < out[j].status
+	})
+	return out
+}
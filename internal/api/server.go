@@ -0,0 +1,182 @@
+// Package api exposes the scheduler over HTTP: a small control surface
+// (list jobs, trigger/pause/resume, per-job history) plus a Prometheus
+// /metrics endpoint. It's started optionally by `crono run --listen`.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/example/crono/internal/scheduler"
+	"github.com/example/crono/internal/store"
+)
+
+// Server wraps an *http.ServeMux bound to a single scheduler.Engine.
+type Server struct {
+	engine  *scheduler.Engine
+	metrics *collector
+	mux     *http.ServeMux
+}
+
+// NewServer builds a Server for engine. It subscribes to the engine's event
+// bus immediately so metrics are collected from the moment the server is
+// constructed, not just once ListenAndServe is called.
+func NewServer(engine *scheduler.Engine) *Server {
+	s := &Server{
+		engine:  engine,
+		metrics: newCollector(engine),
+		mux:     http.NewServeMux(),
+	}
+	go s.metrics.collect(engine.Subscribe())
+
+	s.mux.HandleFunc("/jobs", s.handleJobs)
+	s.mux.HandleFunc("/jobs/", s.handleJob)
+	s.mux.HandleFunc("/metrics", s.metrics.handle)
+	return s
+}
+
+// Handler returns the server's http.Handler, e.g. for use in tests or
+// alongside other routes.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe starts the control/observability API on addr. It blocks
+// until the listener fails or the process exits.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.mux)
+}
+
+type jobView struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	Overlap  string     `json:"overlap"`
+	NextFire *time.Time `json:"next_fire,omitempty"`
+	LastRun  *time.Time `json:"last_run,omitempty"`
+	Status   string     `json:"last_status,omitempty"`
+	Paused   bool       `json:"paused"`
+}
+
+func (s *Server) viewFor(name string) (jobView, bool) {
+	var found bool
+	v := jobView{}
+	for _, j := range s.engine.Jobs() {
+		if j.Name != name {
+			continue
+		}
+		found = true
+		v.Name = j.Name
+		v.Schedule = j.Schedule
+		v.Overlap = j.Overlap
+	}
+	if !found {
+		return v, false
+	}
+	if next, ok := s.engine.NextFire(name); ok {
+		v.NextFire = &next
+	}
+	states, err := s.engine.States()
+	if err == nil {
+		if st, ok := states[name]; ok {
+			if !st.LastFinished.IsZero() {
+				v.LastRun = &st.LastFinished
+			}
+			v.Status = string(st.LastStatus)
+			v.Paused = st.Paused(time.Now())
+		}
+	}
+	return v, true
+}
+
+// GET /jobs - list every configured job with its next fire time, last
+// status, and paused flag.
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	views := make([]jobView, 0, len(s.engine.Jobs()))
+	for _, j := range s.engine.Jobs() {
+		v, _ := s.viewFor(j.Name)
+		views = append(views, v)
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+// /jobs/{name} and /jobs/{name}/{trigger,pause,resume,history}
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	name, action, _ := strings.Cut(rest, "/")
+	if name == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if _, ok := s.viewFor(name); !ok {
+		http.Error(w, "unknown job", http.StatusNotFound)
+		return
+	}
+
+	switch action {
+	case "":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		v, _ := s.viewFor(name)
+		writeJSON(w, http.StatusOK, v)
+	case "trigger":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := s.engine.Trigger(name); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "triggered"})
+	case "pause":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := s.engine.Pause(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "paused"})
+	case "resume":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := s.engine.Resume(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "resumed"})
+	case "history":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		runs, err := s.engine.History(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if runs == nil {
+			runs = []store.RunRecord{}
+		}
+		writeJSON(w, http.StatusOK, runs)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
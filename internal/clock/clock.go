@@ -0,0 +1,33 @@
+// Package clock abstracts the passage of time so the scheduler can be
+// driven deterministically in tests, instead of relying on real sleeps.
+package clock
+
+import "time"
+
+// Clock is the subset of the time package the scheduler needs. The engine
+// uses it everywhere it would otherwise call time.Now/time.After/
+// time.Sleep directly.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// Real is the default Clock, backed by the real wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+// Fixed is a Clock pinned to a single instant. It lets code that takes an
+// arbitrary reference time (e.g. scheduler.NextRun's "from") be expressed
+// in terms of a Clock, by wrapping that instant instead of the live clock.
+// After/Sleep delegate to the real clock, since callers only rely on Fixed
+// for Now().
+type Fixed struct {
+	Real
+	At time.Time
+}
+
+func (f Fixed) Now() time.Time { return f.At }
@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvanceFiresAllDueWaiters(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	var fired []string
+	chA := c.After(10 * time.Minute)
+	chB := c.After(1 * time.Minute)
+	chC := c.After(5 * time.Minute)
+
+	c.Advance(20 * time.Minute)
+
+	drain := func(name string, ch <-chan time.Time) {
+		select {
+		case <-ch:
+			fired = append(fired, name)
+		default:
+			t.Fatalf("%s never fired", name)
+		}
+	}
+	drain("B", chB)
+	drain("C", chC)
+	drain("A", chA)
+
+	if got := c.Now(); !got.Equal(start.Add(20 * time.Minute)) {
+		t.Errorf("Now() = %s, want %s", got, start.Add(20*time.Minute))
+	}
+}
+
+func TestFakeClockAfterDoesNotFireBeforeDeadline(t *testing.T) {
+	c := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := c.After(time.Minute)
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After fired before its deadline")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-ch:
+	default:
+		t.Fatal("After did not fire once its deadline passed")
+	}
+}
+
+func TestFakeClockSleepBlocksUntilAdvance(t *testing.T) {
+	c := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before Advance")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.Advance(time.Hour)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Sleep did not return after Advance")
+	}
+}
@@ -0,0 +1,78 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock that only moves when Advance is called. Tests use it
+// to drive the scheduler through exact, reproducible firing sequences
+// without waiting on real time.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFakeClock returns a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// After returns a channel that fires once Advance has moved the clock to or
+// past f.Now()+d. A non-positive d fires immediately.
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	f.mu.Lock()
+	deadline := f.now.Add(d)
+	if !deadline.After(f.now) {
+		f.mu.Unlock()
+		ch <- deadline
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{deadline: deadline, ch: ch})
+	f.mu.Unlock()
+	return ch
+}
+
+// Sleep blocks the calling goroutine until Advance moves the clock to or
+// past f.Now()+d.
+func (f *FakeClock) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// Advance moves the clock forward by d, firing (in deadline order) every
+// pending After/Sleep waiter whose deadline is now due.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+	var fired []fakeWaiter
+	remaining := f.waiters[:0]
+	for _, w := range f.waiters {
+		if !w.deadline.After(now) {
+			fired = append(fired, w)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	f.waiters = remaining
+	f.mu.Unlock()
+
+	sort.Slice(fired, func(i, j int) bool { return fired[i].deadline.Before(fired[j].deadline) })
+	for _, w := range fired {
+		w.ch <- w.deadline
+	}
+}
@@ -18,23 +18,57 @@ type Program struct {
 type Job struct {
 	Name     string
 	Schedule string // raw schedule expression
-	Run      string // command to run (shell string)
+	Run      string // command to run (shell string, RunKind "sh")
+	RunKind  string // "sh" | "http" | "docker" | "script"
+
+	// HTTP runner (RunKind "http"): run: http POST "url" [body "..."] [expect 2xx]
+	HTTPMethod  string
+	HTTPURL     string
+	HTTPBody    string
+	HTTPExpect  string            // status class to accept, e.g. "2xx" (default "2xx")
+	HTTPHeaders map[string]string // set via header: { KEY: "VALUE" }, request headers for RunKind "http"
+
+	// Container runner (RunKind "docker"): run: docker "image:tag" cmd "..."
+	DockerImage string
+	DockerCmd   string
+
+	// Script runner (RunKind "script"): run: script "path/to/file"
+	ScriptPath string
+
 	RetryN   int
 	BackoffA time.Duration
 	BackoffB time.Duration
 	Timeout  time.Duration
 	Jitter   time.Duration
-	Overlap  string // "skip" | "queue" | "cancel-prev" (only skip implemented)
-	Env      map[string]string
+	Overlap  string // "skip" | "queue" | "cancel-prev"
+	QueueMax int    // for overlap: queue, max pending fire-times before dropping (default 16)
+	Missed   string // "skip" | "run-once" | "run-all" (catch-up policy after a restart)
+
+	// Circuit breaker: after PauseAfter consecutive failed runs, the job is
+	// paused for PauseFor (or, if PauseExp is set, an exponentially growing
+	// duration between PauseForExpMin and PauseForExpMax). PauseAfter == 0
+	// disables the breaker.
+	PauseAfter     int
+	PauseFor       time.Duration
+	PauseExp       bool
+	PauseForExpMin time.Duration
+	PauseForExpMax time.Duration
+
+	Env map[string]string
 }
 
 var (
-	reJobStart   = regexp.MustCompile(`^\s*job\s+"([^"]+)"\s*{\s*$`)
-	reKV         = regexp.MustCompile(`^\s*([a-zA-Z_]+)\s*:\s*(.+?)\s*$`)
-	reJobEnd     = regexp.MustCompile(`^\s*}\s*$`)
-	reString     = regexp.MustCompile(`^"(.*)"$`)
-	reRetry      = regexp.MustCompile(`^(\d+)\s+with\s+backoff\s+([0-9smhd]+)\.\.([0-9smhd]+)$`)
-	reDuration   = regexp.MustCompile(`^([0-9]+)([smhd])$`)
+	reJobStart    = regexp.MustCompile(`^\s*job\s+"([^"]+)"\s*{\s*$`)
+	reKV          = regexp.MustCompile(`^\s*([a-zA-Z_]+)\s*:\s*(.+?)\s*$`)
+	reJobEnd      = regexp.MustCompile(`^\s*}\s*$`)
+	reString      = regexp.MustCompile(`^"(.*)"$`)
+	reRetry       = regexp.MustCompile(`^(\d+)\s+with\s+backoff\s+([0-9smhd]+)\.\.([0-9smhd]+)$`)
+	reDuration    = regexp.MustCompile(`^([0-9]+)([smhd])$`)
+	rePauseForExp = regexp.MustCompile(`^exp\s+([0-9smhd]+)\.\.([0-9smhd]+)$`)
+
+	reHTTPRun   = regexp.MustCompile(`^http\s+(\S+)\s+"([^"]*)"(?:\s+body\s+"([^"]*)")?(?:\s+expect\s+(\w+))?$`)
+	reDockerRun = regexp.MustCompile(`^docker\s+"([^"]+)"\s+cmd\s+"([^"]*)"$`)
+	reScriptRun = regexp.MustCompile(`^script\s+"([^"]+)"$`)
 )
 
 func ParseFile(path string) (*Program, error) {
@@ -61,7 +95,7 @@ func Parse(f *os.File) (*Program, error) {
 		if cur == nil {
 			m := reJobStart.FindStringSubmatch(line)
 			if m != nil {
-				cur = &Job{Name: m[1], RetryN: 0, BackoffA: 0, BackoffB: 0, Timeout: 0, Jitter: 0, Overlap: "skip", Env: map[string]string{}}
+				cur = &Job{Name: m[1], RetryN: 0, BackoffA: 0, BackoffB: 0, Timeout: 0, Jitter: 0, Overlap: "skip", QueueMax: 16, Missed: "skip", Env: map[string]string{}, HTTPHeaders: map[string]string{}}
 				continue
 			}
 			return nil, fmt.Errorf("line %d: expected 'job \"name\" {'", lineno)
@@ -81,25 +115,55 @@ func Parse(f *os.File) (*Program, error) {
 			case "schedule":
 				cur.Schedule = val
 			case "run":
-				// run: sh "echo hello" | exec "cmd"
-				// For MVP, accept: sh "...."
-				if strings.HasPrefix(val, "sh ") {
+				// run: sh "..." | exec "..." | http METHOD "url" [body "..."] [expect 2xx]
+				//      | docker "image:tag" cmd "..." | script "path/to/file"
+				switch {
+				case strings.HasPrefix(val, "sh "):
 					s := strings.TrimSpace(strings.TrimPrefix(val, "sh "))
 					m := reString.FindStringSubmatch(s)
 					if m == nil {
 						return nil, fmt.Errorf("line %d: run: sh \"...\"", lineno)
 					}
+					cur.RunKind = "sh"
 					cur.Run = m[1]
-				} else if strings.HasPrefix(val, "exec ") {
-					// exec "command args"
+				case strings.HasPrefix(val, "exec "):
 					s := strings.TrimSpace(strings.TrimPrefix(val, "exec "))
 					m := reString.FindStringSubmatch(s)
 					if m == nil {
 						return nil, fmt.Errorf("line %d: run: exec \"...\"", lineno)
 					}
+					cur.RunKind = "sh"
 					cur.Run = m[1]
-				} else {
-					return nil, fmt.Errorf("line %d: unknown run (use 'sh' or 'exec')", lineno)
+				case strings.HasPrefix(val, "http "):
+					m := reHTTPRun.FindStringSubmatch(val)
+					if m == nil {
+						return nil, fmt.Errorf(`line %d: run: http METHOD "url" [body "..."] [expect 2xx]`, lineno)
+					}
+					cur.RunKind = "http"
+					cur.HTTPMethod = strings.ToUpper(m[1])
+					cur.HTTPURL = m[2]
+					cur.HTTPBody = m[3]
+					cur.HTTPExpect = m[4]
+					if cur.HTTPExpect == "" {
+						cur.HTTPExpect = "2xx"
+					}
+				case strings.HasPrefix(val, "docker "):
+					m := reDockerRun.FindStringSubmatch(val)
+					if m == nil {
+						return nil, fmt.Errorf(`line %d: run: docker "image:tag" cmd "..."`, lineno)
+					}
+					cur.RunKind = "docker"
+					cur.DockerImage = m[1]
+					cur.DockerCmd = m[2]
+				case strings.HasPrefix(val, "script "):
+					m := reScriptRun.FindStringSubmatch(val)
+					if m == nil {
+						return nil, fmt.Errorf(`line %d: run: script "path/to/file"`, lineno)
+					}
+					cur.RunKind = "script"
+					cur.ScriptPath = m[1]
+				default:
+					return nil, fmt.Errorf("line %d: unknown run (use 'sh', 'exec', 'http', 'docker' or 'script')", lineno)
 				}
 			case "retry":
 				m := reRetry.FindStringSubmatch(val)
@@ -141,31 +205,67 @@ func Parse(f *os.File) (*Program, error) {
 					return nil, fmt.Errorf("line %d: overlap: 'skip' | 'queue' | 'cancel-prev'", lineno)
 				}
 				cur.Overlap = v
-			case "env":
-				// env: { KEY: "VALUE", K2: "V2" }
-				if !strings.HasPrefix(val, "{") || !strings.HasSuffix(val, "}") {
-					return nil, fmt.Errorf("line %d: env: { KEY: \"VALUE\" }", lineno)
+			case "queue_max":
+				n, err := strconv.Atoi(val)
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("line %d: queue_max: expected a positive integer", lineno)
 				}
-				content := strings.TrimSpace(val[1:len(val)-1])
-				if content == "" {
-					continue
+				cur.QueueMax = n
+			case "missed":
+				v := strings.TrimSpace(val)
+				if v != "skip" && v != "run-once" && v != "run-all" {
+					return nil, fmt.Errorf("line %d: missed: 'skip' | 'run-once' | 'run-all'", lineno)
+				}
+				cur.Missed = v
+			case "pause_after":
+				n, err := strconv.Atoi(val)
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("line %d: pause_after: expected a positive integer", lineno)
 				}
-				parts := strings.Split(content, ",")
-				for _, p := range parts {
-					p = strings.TrimSpace(p)
-					if p == "" {
-						continue
+				cur.PauseAfter = n
+			case "pause_for":
+				if m := rePauseForExp.FindStringSubmatch(val); m != nil {
+					a, err := parseShortDuration(m[1])
+					if err != nil {
+						return nil, fmt.Errorf("line %d: %v", lineno, err)
+					}
+					b, err := parseShortDuration(m[2])
+					if err != nil {
+						return nil, fmt.Errorf("line %d: %v", lineno, err)
 					}
-					kv := strings.SplitN(p, ":", 2)
-					if len(kv) != 2 {
-						return nil, fmt.Errorf("line %d: env: invalid entry", lineno)
+					if a > b {
+						return nil, fmt.Errorf("line %d: pause_for: exp min > max", lineno)
 					}
-					k := strings.TrimSpace(kv[0])
-					v := strings.TrimSpace(kv[1])
-					k = strings.Trim(k, "\"")
-					v = strings.Trim(v, "\"")
+					cur.PauseExp = true
+					cur.PauseForExpMin = a
+					cur.PauseForExpMax = b
+					continue
+				}
+				d, err := parseShortDuration(val)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: pause_for: %v", lineno, err)
+				}
+				cur.PauseFor = d
+			case "env":
+				// env: { KEY: "VALUE", K2: "V2" } -- process environment
+				// variables, passed to the sh/docker/script runners.
+				m, err := parseKVBlock(val)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: env: %v", lineno, err)
+				}
+				for k, v := range m {
 					cur.Env[k] = v
 				}
+			case "header":
+				// header: { KEY: "VALUE" } -- HTTP request headers, used by
+				// the http runner only (RunKind "http").
+				m, err := parseKVBlock(val)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: header: %v", lineno, err)
+				}
+				for k, v := range m {
+					cur.HTTPHeaders[k] = v
+				}
 			default:
 				return nil, fmt.Errorf("line %d: unknown key '%s'", lineno, key)
 			}
@@ -180,6 +280,57 @@ func Parse(f *os.File) (*Program, error) {
 	return &prog, nil
 }
 
+// parseKVBlock parses a `{ KEY: "VALUE", K2: "V2" }` block, as used by both
+// env: and header:, into a map. An empty block ("{}") is valid and yields
+// an empty map.
+func parseKVBlock(val string) (map[string]string, error) {
+	if !strings.HasPrefix(val, "{") || !strings.HasSuffix(val, "}") {
+		return nil, errors.New(`expected { KEY: "VALUE" }`)
+	}
+	out := map[string]string{}
+	content := strings.TrimSpace(val[1 : len(val)-1])
+	if content == "" {
+		return out, nil
+	}
+	for _, p := range splitKVEntries(content) {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		kv := strings.SplitN(p, ":", 2)
+		if len(kv) != 2 {
+			return nil, errors.New("invalid entry")
+		}
+		k := strings.Trim(strings.TrimSpace(kv[0]), "\"")
+		v := strings.Trim(strings.TrimSpace(kv[1]), "\"")
+		out[k] = v
+	}
+	return out, nil
+}
+
+// splitKVEntries splits a block's content on top-level commas, ignoring
+// commas inside a quoted value (e.g. `Accept: "text/html, text/plain"`
+// must stay one entry).
+func splitKVEntries(content string) []string {
+	var entries []string
+	var cur strings.Builder
+	inQuotes := false
+	for _, r := range content {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case r == ',' && !inQuotes:
+			entries = append(entries, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	entries = append(entries, cur.String())
+	return entries
+}
+
 func parseShortDuration(s string) (time.Duration, error) {
 	m := reDuration.FindStringSubmatch(strings.TrimSpace(s))
 	if m == nil {
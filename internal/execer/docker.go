@@ -0,0 +1,32 @@
+package execer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/example/crono/internal/dsl"
+)
+
+// dockerRunner backs RunKind "docker" (DSL `run: docker "image:tag" cmd
+// "..."`). It shells out to `docker run --rm`, passing the job's env
+// through as -e flags.
+type dockerRunner struct{}
+
+func (dockerRunner) Run(ctx context.Context, j dsl.Job) error {
+	var b strings.Builder
+	b.WriteString("docker run --rm")
+	for k, v := range j.Env {
+		b.WriteString(" -e " + shellQuote(k+"="+v))
+	}
+	b.WriteString(" " + shellQuote(j.DockerImage))
+	if j.DockerCmd != "" {
+		b.WriteString(" " + j.DockerCmd)
+	}
+	return RunShell(ctx, b.String(), nil)
+}
+
+// shellQuote wraps s in single quotes for /bin/sh, escaping any embedded
+// single quote the POSIX way.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
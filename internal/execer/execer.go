@@ -1,47 +1,54 @@
+// Package execer runs a job's `run:` command. A Job may select one of
+// several runtimes (shell, HTTP, container, script); Run dispatches to the
+// matching Runner.
 package execer
 
 import (
 	"context"
 	"errors"
-	"os/exec"
-	"runtime"
-	"time"
+	"fmt"
+
+	"github.com/example/crono/internal/dsl"
 )
 
-// RunShell runs the given command string using the platform shell.
-// - Windows: cmd /C "<cmd>"
-// - Others : /bin/sh -c "<cmd>"
-// Environment variables from env map are injected.
-func RunShell(ctx context.Context, command string, env map[string]string) error {
-	var c *exec.Cmd
-	if runtime.GOOS == "windows" {
-		c = exec.CommandContext(ctx, "cmd", "/C", command)
-	} else {
-		c = exec.CommandContext(ctx, "/bin/sh", "-c", command)
-	}
-	// attach env
-	if len(env) > 0 {
-		en := make([]string, 0, len(env))
-		for k, v := range env {
-			en = append(en, k+"="+v)
-		}
-		c.Env = append(c.Env, en...)
-	}
-	// run
-	if err := c.Start(); err != nil {
-		return err
+// Runner executes a single job invocation.
+type Runner interface {
+	Run(ctx context.Context, j dsl.Job) error
+}
+
+// PermanentError wraps a failure that a job's retry policy should not act
+// on (e.g. an HTTP 4xx response to a `run: http` job) -- the scheduler
+// treats it as an immediate, final failure regardless of `retry:`.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err (or one it wraps) is a PermanentError.
+func IsPermanent(err error) bool {
+	var pe *PermanentError
+	return errors.As(err, &pe)
+}
+
+var runners = map[string]Runner{
+	"sh":     shellRunner{},
+	"http":   httpRunner{},
+	"docker": dockerRunner{},
+	"script": scriptRunner{},
+}
+
+// Run dispatches j to the Runner matching its RunKind ("sh" if unset, for
+// jobs parsed before RunKind existed).
+func Run(ctx context.Context, j dsl.Job) error {
+	kind := j.RunKind
+	if kind == "" {
+		kind = "sh"
 	}
-	done := make(chan error, 1)
-	go func() { done <- c.Wait() }()
-
-	select {
-	case <-ctx.Done():
-		_ = c.Process.Kill()
-		return errors.New("timeout/canceled")
-	case err := <-done:
-		return err
-	case <-time.After(24 * time.Hour):
-		_ = c.Process.Kill()
-		return errors.New("guard-timeout")
+	r, ok := runners[kind]
+	if !ok {
+		return fmt.Errorf("execer: unknown run kind %q", kind)
 	}
+	return r.Run(ctx, j)
 }
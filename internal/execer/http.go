@@ -0,0 +1,66 @@
+package execer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/example/crono/internal/dsl"
+)
+
+// httpRunner backs RunKind "http" (DSL `run: http METHOD "url" [body "..."]
+// [expect 2xx]`). Only a 5xx response or a transport-level error (timeout,
+// connection refused, ...) is retryable; a 4xx response is a PermanentError
+// so the job's `retry:` policy doesn't waste attempts on a request that
+// will never succeed unmodified.
+type httpRunner struct{}
+
+func (httpRunner) Run(ctx context.Context, j dsl.Job) error {
+	var body io.Reader
+	if j.HTTPBody != "" {
+		body = strings.NewReader(j.HTTPBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, j.HTTPMethod, j.HTTPURL, body)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("http: %w", err)}
+	}
+	for k, v := range j.HTTPHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// Transport errors (including ctx deadline/timeout) are retryable.
+		return fmt.Errorf("http: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if matchesExpect(resp.StatusCode, j.HTTPExpect) {
+		return nil
+	}
+	err = fmt.Errorf("http: %s %s: unexpected status %d", j.HTTPMethod, j.HTTPURL, resp.StatusCode)
+	if resp.StatusCode >= 500 {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
+
+// matchesExpect checks status against an "expect" class like "2xx" or an
+// exact code like "204".
+func matchesExpect(status int, expect string) bool {
+	if expect == "" {
+		expect = "2xx"
+	}
+	if strings.HasSuffix(expect, "xx") && len(expect) == 3 {
+		class, err := strconv.Atoi(expect[:1])
+		if err == nil {
+			return status/100 == class
+		}
+	}
+	code, err := strconv.Atoi(expect)
+	return err == nil && status == code
+}
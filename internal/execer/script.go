@@ -0,0 +1,61 @@
+package execer
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/example/crono/internal/dsl"
+)
+
+// scriptRunner backs RunKind "script" (DSL `run: script "path/to/file"`).
+// If the file is executable and starts with a shebang, it's run directly;
+// otherwise it falls back to an interpreter chosen by file extension, and a
+// file with an unrecognized extension is run directly.
+type scriptRunner struct{}
+
+func (scriptRunner) Run(ctx context.Context, j dsl.Job) error {
+	path := j.ScriptPath
+	if hasExecutableShebang(path) {
+		return RunShell(ctx, shellQuote(path), j.Env)
+	}
+	interp := interpreterFor(path)
+	if interp == "" {
+		return RunShell(ctx, shellQuote(path), j.Env)
+	}
+	return RunShell(ctx, interp+" "+shellQuote(path), j.Env)
+}
+
+// hasExecutableShebang reports whether path starts with "#!" and has its
+// executable bit set, meaning it can be run directly.
+func hasExecutableShebang(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() || !strings.HasPrefix(sc.Text(), "#!") {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&0o111 != 0
+}
+
+// interpreterFor picks a fallback interpreter by file extension when a
+// script has no usable shebang.
+func interpreterFor(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".py"):
+		return "python3"
+	case strings.HasSuffix(path, ".rb"):
+		return "ruby"
+	case strings.HasSuffix(path, ".js"):
+		return "node"
+	case strings.HasSuffix(path, ".sh"):
+		return "/bin/sh"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,227 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldSet is the set of allowed values for one cron field.
+type fieldSet map[int]bool
+
+// cronSpec is a parsed 5-field crontab expression.
+type cronSpec struct {
+	min, hour, dom, mon, dow                fieldSet
+	minAny, hourAny, domAny, monAny, dowAny bool
+}
+
+var dowNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+var monNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// maxCronYears bounds how far nextCron will search before giving up,
+// so a field combination that can never match (e.g. "30 * 31 2 *") fails
+// fast instead of looping forever.
+const maxCronYears = 5
+
+// atShortcuts maps the @-shortcut forms to their crontab equivalent.
+var atShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// atShortcutExplain gives a plain-English rendering for each @-shortcut.
+var atShortcutExplain = map[string]string{
+	"@yearly":   "once a year, at midnight on Jan 1",
+	"@annually": "once a year, at midnight on Jan 1",
+	"@monthly":  "once a month, at midnight on the 1st",
+	"@weekly":   "once a week, at midnight on Sunday",
+	"@daily":    "once a day, at midnight",
+	"@hourly":   "once an hour, at minute 0",
+}
+
+// parseCron parses a standard 5-field crontab expression
+// (minute hour day-of-month month day-of-week), supporting "*", "?",
+// ranges ("a-b"), steps ("*/n", "a-b/n"), lists ("a,b,c") and the
+// standard month/day-of-week name abbreviations.
+func parseCron(spec string) (cronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return cronSpec{}, fmt.Errorf("cron: expected 5 fields (min hour dom month dow), got %d", len(fields))
+	}
+	var cs cronSpec
+	var err error
+	if cs.min, cs.minAny, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("minute: %w", err)
+	}
+	if cs.hour, cs.hourAny, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("hour: %w", err)
+	}
+	if cs.dom, cs.domAny, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("day-of-month: %w", err)
+	}
+	if cs.mon, cs.monAny, err = parseCronField(fields[3], 1, 12, monNames); err != nil {
+		return cronSpec{}, fmt.Errorf("month: %w", err)
+	}
+	if cs.dow, cs.dowAny, err = parseCronField(fields[4], 0, 6, dowNames); err != nil {
+		return cronSpec{}, fmt.Errorf("day-of-week: %w", err)
+	}
+	return cs, nil
+}
+
+// parseCronField parses a single comma-separated cron field into the set
+// of values it allows. It reports (nil, true, nil) for "*"/"?" ("any"),
+// which callers must treat as "unconstrained" rather than an empty set.
+func parseCronField(field string, min, max int, names map[string]int) (fieldSet, bool, error) {
+	field = strings.TrimSpace(field)
+	if field == "*" || field == "?" {
+		return nil, true, nil
+	}
+	set := fieldSet{}
+	for _, part := range strings.Split(field, ",") {
+		part = strings.TrimSpace(part)
+		base := part
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			base = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, false, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+		lo, hi := min, max
+		switch {
+		case base == "*":
+			// lo/hi already cover the whole field
+		case strings.Contains(base, "-"):
+			a, b, _ := strings.Cut(base, "-")
+			av, err := parseCronValue(a, names)
+			if err != nil {
+				return nil, false, err
+			}
+			bv, err := parseCronValue(b, names)
+			if err != nil {
+				return nil, false, err
+			}
+			lo, hi = av, bv
+		default:
+			v, err := parseCronValue(base, names)
+			if err != nil {
+				return nil, false, err
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = max // "N/step" means "every step, starting at N"
+			}
+		}
+		if lo > hi || lo < min || hi > max {
+			return nil, false, fmt.Errorf("value out of range [%d,%d] in %q", min, max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, false, nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	s = strings.TrimSpace(s)
+	if names != nil {
+		if v, ok := names[strings.ToLower(s)]; ok {
+			return v, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// nextCron returns the next time at or after from+1m that satisfies spec,
+// computed in loc. It bumps the smallest overflowing field (minute, then
+// hour, then day, then month) rather than testing every minute, bounded by
+// maxCronYears so an unsatisfiable spec (e.g. Feb 30) fails instead of
+// looping forever.
+func nextCron(from time.Time, spec string, loc *time.Location) (time.Time, error) {
+	cs, err := parseCron(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t := from.In(loc).Truncate(time.Minute).Add(time.Minute)
+	deadline := t.AddDate(maxCronYears, 0, 0)
+	for {
+		if t.After(deadline) {
+			return time.Time{}, fmt.Errorf("cron: no match for %q within %d years", spec, maxCronYears)
+		}
+		if !cs.monAny && !cs.mon[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, 1, 0)
+			continue
+		}
+		if !cronDayMatches(cs, t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+			continue
+		}
+		if !cs.hourAny && !cs.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(time.Hour)
+			continue
+		}
+		if !cs.minAny && !cs.min[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t.In(from.Location()), nil
+	}
+}
+
+// cronDayMatches applies the classic crontab quirk: if both day-of-month
+// and day-of-week are restricted, a day matching either one is enough.
+func cronDayMatches(cs cronSpec, t time.Time) bool {
+	if cs.domAny && cs.dowAny {
+		return true
+	}
+	if cs.domAny {
+		return cs.dow[int(t.Weekday())]
+	}
+	if cs.dowAny {
+		return cs.dom[t.Day()]
+	}
+	return cs.dom[t.Day()] || cs.dow[int(t.Weekday())]
+}
+
+// explainCronSpec renders the raw 5-field string in plain English.
+func explainCronSpec(spec string) string {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return spec
+	}
+	return strings.Join([]string{
+		describeCronField(fields[0], "minute"),
+		describeCronField(fields[1], "hour"),
+		describeCronField(fields[2], "day of month"),
+		describeCronField(fields[3], "month"),
+		describeCronField(fields[4], "day of week"),
+	}, ", ")
+}
+
+func describeCronField(raw, label string) string {
+	switch {
+	case raw == "*" || raw == "?":
+		return "any " + label
+	case strings.HasPrefix(raw, "*/"):
+		return "every " + strings.TrimPrefix(raw, "*/") + " " + label + "(s)"
+	default:
+		return label + " " + raw
+	}
+}
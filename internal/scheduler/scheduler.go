@@ -6,43 +6,252 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/example/crono/internal/clock"
 	"github.com/example/crono/internal/dsl"
 	"github.com/example/crono/internal/execer"
+	"github.com/example/crono/internal/store"
 )
 
+// reCronLine matches the payload of a `cron "<5 fields>" [TZ]` schedule,
+// capturing the quoted crontab expression and an optional trailing TZ token.
+var reCronLine = regexp.MustCompile(`^"([^"]+)"\s*(\S+)?$`)
+
+// defaultMissedWindow bounds how far back catch-up looks for missed
+// occurrences, and defaultMaxMissedRuns bounds how many it will enqueue for
+// a `missed: run-all` job, so a process that was down for a long time
+// doesn't replay an unbounded backlog.
+const (
+	defaultMissedWindow  = 24 * time.Hour
+	defaultMaxMissedRuns = 100
+
+	// defaultQueueMax is used for `overlap: queue` jobs whose DSL didn't
+	// set queue_max.
+	defaultQueueMax = 16
+
+	// cancelPrevGrace is how long `overlap: cancel-prev` waits for the
+	// previous invocation to actually exit after its context is canceled,
+	// before starting the new one anyway.
+	cancelPrevGrace = 5 * time.Second
+)
+
+// queuedFire is one pending occurrence waiting in a job's `overlap: queue`
+// FIFO.
+type queuedFire struct {
+	job     dsl.Job
+	firedAt time.Time
+}
+
+// EventType identifies what happened to a job.
+type EventType string
+
+const (
+	EventScheduled EventType = "scheduled"
+	EventStarted   EventType = "started"
+	EventFinished  EventType = "finished"
+	EventPaused    EventType = "paused"
+)
+
+// Event is published on the Engine's event bus (see Subscribe) so the
+// internal/api package can keep its /metrics run counts, durations and
+// in-flight gauges without polling the engine's busy map.
+type Event struct {
+	Job      string
+	Type     EventType
+	At       time.Time
+	Status   store.Status  // set on EventFinished
+	Duration time.Duration // set on EventFinished
+}
+
 // Engine runs jobs based on their schedule.
 type Engine struct {
 	prog dsl.Program
 	mu   sync.Mutex
 	busy map[string]bool
+	next map[string]time.Time // last computed next-fire time per job
+
+	runCtx context.Context // the context passed to Run, used by Trigger
+
+	// overlap: queue - one buffered channel and drain goroutine per job.
+	queues map[string]chan queuedFire
+
+	// overlap: cancel-prev - the running invocation's cancel func and a
+	// channel closed when it actually returns.
+	cancels map[string]context.CancelFunc
+	done    map[string]chan struct{}
+
+	subMu sync.Mutex
+	subs  []chan Event
+
+	store         store.Store
+	MissedWindow  time.Duration // how far back to look for missed occurrences
+	MaxMissedRuns int           // cap on occurrences replayed for `missed: run-all`
+
+	// Clock is the source of time for everything the engine does after
+	// construction (waiting for the next fire, backoff sleeps, timestamps).
+	// It defaults to clock.Real{}; tests override it with a
+	// clock.FakeClock to drive the engine deterministically.
+	Clock clock.Clock
 }
 
+// NewEngine builds an Engine with in-memory (non-persistent) job state.
 func NewEngine(p *dsl.Program) *Engine {
-	return &Engine{prog: *p, busy: map[string]bool{}}
+	return NewEngineWithStore(p, store.NewMemStore())
+}
+
+// NewEngineWithStore builds an Engine whose job state (last run, last
+// status, attempt count) is persisted through st, so a restart can replay
+// missed occurrences per each job's `missed:` policy.
+func NewEngineWithStore(p *dsl.Program, st store.Store) *Engine {
+	return &Engine{
+		prog:          *p,
+		busy:          map[string]bool{},
+		next:          map[string]time.Time{},
+		queues:        map[string]chan queuedFire{},
+		cancels:       map[string]context.CancelFunc{},
+		done:          map[string]chan struct{}{},
+		store:         st,
+		MissedWindow:  defaultMissedWindow,
+		MaxMissedRuns: defaultMaxMissedRuns,
+		Clock:         clock.Real{},
+	}
+}
+
+// Jobs returns the engine's configured jobs.
+func (e *Engine) Jobs() []dsl.Job {
+	return append([]dsl.Job(nil), e.prog.Jobs...)
+}
+
+// States returns a snapshot of persisted per-job state.
+func (e *Engine) States() (map[string]store.JobState, error) {
+	return e.store.Load()
+}
+
+// History returns name's recorded runs, oldest first.
+func (e *Engine) History(name string) ([]store.RunRecord, error) {
+	return e.store.History(name)
+}
+
+// NextFire returns the next time name is due to fire, and whether it has
+// one scheduled (one-shot jobs stop having one once they've fired).
+func (e *Engine) NextFire(name string) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	t, ok := e.next[name]
+	return t, ok
+}
+
+// Subscribe returns a channel of events (scheduled/started/finished/paused)
+// for every job. The channel is buffered; a subscriber that falls behind
+// has events dropped rather than blocking the engine.
+func (e *Engine) Subscribe() <-chan Event {
+	ch := make(chan Event, 64)
+	e.subMu.Lock()
+	e.subs = append(e.subs, ch)
+	e.subMu.Unlock()
+	return ch
+}
+
+func (e *Engine) emit(ev Event) {
+	e.subMu.Lock()
+	defer e.subMu.Unlock()
+	for _, ch := range e.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func (e *Engine) jobByName(name string) (dsl.Job, bool) {
+	for _, j := range e.prog.Jobs {
+		if j.Name == name {
+			return j, true
+		}
+	}
+	return dsl.Job{}, false
+}
+
+// Trigger fires name immediately, respecting its overlap policy, as if it
+// had just come due. It returns an error if name is unknown, or currently
+// paused.
+func (e *Engine) Trigger(name string) error {
+	e.mu.Lock()
+	ctx := e.runCtx
+	e.mu.Unlock()
+	if ctx == nil {
+		return fmt.Errorf("engine is not running")
+	}
+	j, ok := e.jobByName(name)
+	if !ok {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	if paused, _ := e.paused(name); paused {
+		return fmt.Errorf("job %q is paused", name)
+	}
+	now := e.Clock.Now()
+	_ = e.store.MarkScheduled(j.Name, now)
+	e.emit(Event{Job: j.Name, Type: EventScheduled, At: now})
+	e.dispatch(ctx, j, now)
+	return nil
+}
+
+// Pause pauses name until Resume is called.
+func (e *Engine) Pause(name string) error {
+	if _, ok := e.jobByName(name); !ok {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	if err := e.store.SetManualPause(name); err != nil {
+		return err
+	}
+	e.emit(Event{Job: name, Type: EventPaused, At: e.Clock.Now()})
+	return nil
+}
+
+// Resume clears both a manual pause and a tripped circuit breaker for name.
+func (e *Engine) Resume(name string) error {
+	if _, ok := e.jobByName(name); !ok {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	return e.store.ClearPause(name)
 }
 
 func (e *Engine) Run(ctx context.Context) error {
+	e.mu.Lock()
+	e.runCtx = ctx
+	e.mu.Unlock()
+
 	type item struct {
-		job dsl.Job
+		job  dsl.Job
 		next time.Time
 	}
 	// Initial planning
 	items := make([]item, 0, len(e.prog.Jobs))
-	now := time.Now()
+	now := e.Clock.Now()
 	for _, j := range e.prog.Jobs {
-		n, err := NextRun(j.Schedule, now)
+		n, err := NextRun(j.Schedule, e.Clock)
 		if err != nil {
 			return fmt.Errorf("job %q: %w", j.Name, err)
 		}
 		items = append(items, item{job: j, next: n})
+		e.mu.Lock()
+		e.next[j.Name] = n
+		e.mu.Unlock()
 	}
-	// Simple loop (no persistence)
+
+	e.replayMissed(ctx, now)
+
 	for {
+		if len(items) == 0 {
+			// every job was one-shot and has already fired
+			<-ctx.Done()
+			return ctx.Err()
+		}
 		// find earliest
 		soon := 0
 		for i := range items {
@@ -50,56 +259,239 @@ func (e *Engine) Run(ctx context.Context) error {
 				soon = i
 			}
 		}
-		wait := time.Until(items[soon].next)
+		wait := items[soon].next.Sub(e.Clock.Now())
 		if wait > 0 {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(wait):
+			case <-e.Clock.After(wait):
 			}
 		}
 		j := items[soon].job
+		firedAt := items[soon].next
+		_ = e.store.MarkScheduled(j.Name, firedAt)
+		e.emit(Event{Job: j.Name, Type: EventScheduled, At: firedAt})
 
-		// overlap policy
-		e.mu.Lock()
-		if e.busy[j.Name] {
-			if j.Overlap == "skip" {
-				log.Printf("[%-16s] chevauchement: skip", j.Name)
-				e.mu.Unlock()
+		if paused, until := e.paused(j.Name); paused {
+			if until.IsZero() {
+				log.Printf("[%-16s] en pause (manuelle), occurrence ignoree", j.Name)
 			} else {
-				// queue (not implemented) => skip for now
-				log.Printf("[%-16s] chevauchement: not-implemented(%s) => skip", j.Name, j.Overlap)
-				e.mu.Unlock()
+				log.Printf("[%-16s] en pause jusqu'a %s, occurrence ignoree", j.Name, until.Format(time.RFC3339))
 			}
 		} else {
-			e.busy[j.Name] = true
-			e.mu.Unlock()
+			e.dispatch(ctx, j, firedAt)
+		}
 
-			go func(j dsl.Job) {
-				defer func() {
-					e.mu.Lock()
-					delete(e.busy, j.Name)
-					e.mu.Unlock()
-				}()
-				e.runOnce(ctx, j)
-			}(j)
+		if IsOneShot(j.Schedule) {
+			log.Printf("[%-16s] planification unique epuisee, retrait", j.Name)
+			items = append(items[:soon], items[soon+1:]...)
+			e.mu.Lock()
+			delete(e.next, j.Name)
+			e.mu.Unlock()
+			continue
 		}
 
 		// schedule next
-		next, err := NextRun(j.Schedule, items[soon].next.Add(time.Second))
+		next, err := NextRun(j.Schedule, clock.Fixed{At: firedAt.Add(time.Second)})
 		if err != nil {
 			log.Printf("planif suivante échouée pour %q: %v", j.Name, err)
-			next = time.Now().Add(time.Minute)
+			next = e.Clock.Now().Add(time.Minute)
 		}
 		items[soon].next = next
+		e.mu.Lock()
+		e.next[j.Name] = next
+		e.mu.Unlock()
 	}
 }
 
+// replayMissed looks at each job's recorded last_scheduled and, per its
+// `missed:` policy, replays occurrences that fell due while the process
+// was down. It's called once at startup, before the main loop begins
+// waiting on the next live tick.
+func (e *Engine) replayMissed(ctx context.Context, now time.Time) {
+	states, err := e.store.Load()
+	if err != nil {
+		log.Printf("store: chargement echoue: %v", err)
+		return
+	}
+	for _, j := range e.prog.Jobs {
+		policy := j.Missed
+		if policy == "" || policy == "skip" {
+			continue
+		}
+		st, ok := states[j.Name]
+		if !ok || st.LastScheduled.IsZero() {
+			continue
+		}
+		windowStart := now.Add(-e.MissedWindow)
+		from := st.LastScheduled
+		if from.Before(windowStart) {
+			from = windowStart
+		}
+		var missed []time.Time
+		t := from
+		for len(missed) < e.MaxMissedRuns {
+			next, err := NextRun(j.Schedule, clock.Fixed{At: t})
+			if err != nil || !next.Before(now) {
+				break
+			}
+			missed = append(missed, next)
+			t = next.Add(time.Second)
+		}
+		if len(missed) == 0 {
+			continue
+		}
+		switch policy {
+		case "run-once":
+			log.Printf("[%-16s] rattrapage: %d occurrence(s) manquee(s), une seule execution", j.Name, len(missed))
+			e.fireNow(ctx, j, missed[len(missed)-1])
+		case "run-all":
+			log.Printf("[%-16s] rattrapage: execution de %d occurrence(s) manquee(s)", j.Name, len(missed))
+			for _, m := range missed {
+				e.fireNow(ctx, j, m)
+			}
+		default:
+			log.Printf("[%-16s] missed: valeur inconnue %q, ignoree", j.Name, policy)
+		}
+	}
+}
+
+// dispatch fires j according to its overlap policy:
+//   - "skip" (default): run, unless a previous invocation is still busy.
+//   - "queue": enqueue onto a per-job FIFO drained by a single worker
+//     goroutine, so occurrences run one at a time, in order.
+//   - "cancel-prev": cancel the running invocation's context, give it
+//     cancelPrevGrace to exit, then start the new one regardless.
+func (e *Engine) dispatch(ctx context.Context, j dsl.Job, firedAt time.Time) {
+	switch j.Overlap {
+	case "queue":
+		ch := e.queueFor(ctx, j)
+		select {
+		case ch <- queuedFire{job: j, firedAt: firedAt}:
+		default:
+			max := j.QueueMax
+			if max <= 0 {
+				max = defaultQueueMax
+			}
+			log.Printf("[%-16s] file pleine (queue_max=%d), occurrence perdue", j.Name, max)
+		}
+	case "cancel-prev":
+		e.mu.Lock()
+		cancel, running := e.cancels[j.Name]
+		done := e.done[j.Name]
+		e.mu.Unlock()
+		if running {
+			log.Printf("[%-16s] cancel-prev: annulation de l'execution en cours", j.Name)
+			cancel()
+			select {
+			case <-done:
+			case <-e.Clock.After(cancelPrevGrace):
+				log.Printf("[%-16s] cancel-prev: l'execution precedente ne s'est pas terminee a temps", j.Name)
+			}
+		}
+		e.startRun(ctx, j)
+	default: // "skip"
+		e.mu.Lock()
+		busy := e.busy[j.Name]
+		e.mu.Unlock()
+		if busy {
+			log.Printf("[%-16s] chevauchement: skip", j.Name)
+			return
+		}
+		e.startRun(ctx, j)
+	}
+}
+
+// startRun runs j in its own goroutine under a cancelable context, tracking
+// it in busy/cancels/done so overlap policies can observe and control it.
+func (e *Engine) startRun(ctx context.Context, j dsl.Job) {
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	e.mu.Lock()
+	e.busy[j.Name] = true
+	e.cancels[j.Name] = cancel
+	e.done[j.Name] = done
+	e.mu.Unlock()
+
+	go func() {
+		defer func() {
+			e.mu.Lock()
+			delete(e.busy, j.Name)
+			delete(e.cancels, j.Name)
+			delete(e.done, j.Name)
+			e.mu.Unlock()
+			cancel()
+			close(done)
+		}()
+		e.runOnce(runCtx, j)
+	}()
+}
+
+// queueFor returns the FIFO channel for j's `overlap: queue` occurrences,
+// starting its single drain goroutine the first time it's needed.
+func (e *Engine) queueFor(ctx context.Context, j dsl.Job) chan<- queuedFire {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if ch, ok := e.queues[j.Name]; ok {
+		return ch
+	}
+	max := j.QueueMax
+	if max <= 0 {
+		max = defaultQueueMax
+	}
+	ch := make(chan queuedFire, max)
+	e.queues[j.Name] = ch
+	go e.drainQueue(ctx, j.Name, ch)
+	return ch
+}
+
+// drainQueue runs each queued occurrence for name to completion, in order,
+// one at a time, until ctx is canceled.
+func (e *Engine) drainQueue(ctx context.Context, name string, ch <-chan queuedFire) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case qf := <-ch:
+			e.mu.Lock()
+			e.busy[name] = true
+			e.mu.Unlock()
+			e.runOnce(ctx, qf.job)
+			e.mu.Lock()
+			delete(e.busy, name)
+			e.mu.Unlock()
+		}
+	}
+}
+
+// fireNow runs j synchronously for a catch-up occurrence that was
+// originally due at scheduledFor, respecting the busy map like a live tick.
+func (e *Engine) fireNow(ctx context.Context, j dsl.Job, scheduledFor time.Time) {
+	_ = e.store.MarkScheduled(j.Name, scheduledFor)
+	e.mu.Lock()
+	if e.busy[j.Name] {
+		e.mu.Unlock()
+		log.Printf("[%-16s] rattrapage ignore: deja en cours", j.Name)
+		return
+	}
+	e.busy[j.Name] = true
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.busy, j.Name)
+		e.mu.Unlock()
+	}()
+	e.runOnce(ctx, j)
+}
+
 func (e *Engine) runOnce(ctx context.Context, j dsl.Job) {
-	start := time.Now()
+	start := e.Clock.Now()
 	log.Printf("[%-16s] start (pid=%d)", j.Name, osGetpid())
+	_ = e.store.MarkStarted(j.Name, start, 0)
+	e.emit(Event{Job: j.Name, Type: EventStarted, At: start})
 	defer func() {
-		log.Printf("[%-16s] done in %s", j.Name, time.Since(start))
+		log.Printf("[%-16s] done in %s", j.Name, e.Clock.Now().Sub(start))
 	}()
 
 	var attempt int
@@ -117,15 +509,18 @@ func (e *Engine) runOnce(ctx context.Context, j dsl.Job) {
 			runCtx, cancel = context.WithTimeout(ctx, j.Timeout)
 			defer cancel()
 		}
-		err := execer.RunShell(runCtx, j.Run, j.Env)
+		err := execer.Run(runCtx, j)
 		if err == nil {
+			e.recordResult(j, start, store.StatusSuccess)
 			return
 		}
 		attempt++
-		if attempt > j.RetryN {
+		if attempt > j.RetryN || execer.IsPermanent(err) {
 			log.Printf("[%-16s] échec: %v (abandon après %d tentatives)", j.Name, err, attempt)
+			e.recordResult(j, start, store.StatusFailure)
 			return
 		}
+		_ = e.store.MarkStarted(j.Name, start, attempt)
 		// exponential backoff bounded
 		bo := time.Duration(1<<uint(min(attempt, 6))) * backoffMin
 		if bo > backoffMax {
@@ -136,27 +531,146 @@ func (e *Engine) runOnce(ctx context.Context, j dsl.Job) {
 		log.Printf("[%-16s] tentative %d échouée: %v -> retry dans %s", j.Name, attempt, err, sleep)
 		select {
 		case <-runCtx.Done():
+			e.recordResult(j, start, store.StatusFailure)
 			return
-		case <-time.After(sleep):
+		case <-e.Clock.After(sleep):
+		}
+	}
+}
+
+// paused reports whether j is currently paused, either manually (via
+// `crono pause`) or by the circuit breaker, and the time it's paused until
+// (zero for a manual pause, which has no automatic expiry).
+func (e *Engine) paused(name string) (bool, time.Time) {
+	states, err := e.store.Load()
+	if err != nil {
+		return false, time.Time{}
+	}
+	st, ok := states[name]
+	if !ok || !st.Paused(e.Clock.Now()) {
+		return false, time.Time{}
+	}
+	if st.ManualPause {
+		return true, time.Time{}
+	}
+	return true, st.PausedUntil
+}
+
+// recordResult persists the outcome of a run and, once PauseAfter
+// consecutive failures accumulate, trips the circuit breaker for PauseFor
+// (or an exponentially growing duration when PauseExp is set).
+func (e *Engine) recordResult(j dsl.Job, started time.Time, status store.Status) {
+	finishedAt := e.Clock.Now()
+	streak, err := e.store.MarkFinished(j.Name, finishedAt, status)
+	if err != nil {
+		log.Printf("store: echec enregistrement du resultat pour %q: %v", j.Name, err)
+		return
+	}
+	e.emit(Event{Job: j.Name, Type: EventFinished, At: finishedAt, Status: status, Duration: finishedAt.Sub(started)})
+	if status != store.StatusFailure || j.PauseAfter <= 0 || streak < j.PauseAfter {
+		return
+	}
+	dur := j.PauseFor
+	if j.PauseExp {
+		dur = expPause(j.PauseForExpMin, j.PauseForExpMax, streak-j.PauseAfter+1)
+	}
+	if dur <= 0 {
+		dur = time.Minute
+	}
+	until := e.Clock.Now().Add(dur)
+	if err := e.store.SetPausedUntil(j.Name, until); err != nil {
+		log.Printf("store: echec pause pour %q: %v", j.Name, err)
+		return
+	}
+	e.emit(Event{Job: j.Name, Type: EventPaused, At: until})
+	log.Printf("[%-16s] circuit breaker: %d echecs consecutifs, pause jusqu'a %s", j.Name, streak, until.Format(time.RFC3339))
+}
+
+// expPause doubles from min on each consecutive trip beyond the threshold,
+// capped at max.
+func expPause(min, max time.Duration, trip int) time.Duration {
+	if min <= 0 {
+		min = time.Minute
+	}
+	d := min
+	for i := 1; i < trip; i++ {
+		d *= 2
+		if d >= max {
+			return max
 		}
 	}
+	if max > 0 && d > max {
+		d = max
+	}
+	return d
 }
 
-func min(a, b int) int { if a < b { return a }; return b }
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
 
 // --------- Scheduling ---------
 
 // Supported schedule forms (MVP):
-// - "every 5m"
-// - "every weekday at HH:MM [TZ]"
-// - "every day at HH:MM [TZ]"
-// - "at HH:MM [TZ]" (alias of every day at)
-// - "every Nh|Nm|Ns|Nd starting at HH:MM" (starting at is ignored for MVP)
+//   - "every 5m"
+//   - "every weekday at HH:MM [TZ]"
+//   - "every day at HH:MM [TZ]"
+//   - "at HH:MM [TZ]" (alias of every day at)
+//   - "every Nh|Nm|Ns|Nd starting at HH:MM" (fires at HH:MM today plus
+//     whole multiples of the interval)
+//   - `cron "min hour dom month dow" [TZ]` (classic 5-field crontab syntax:
+//     "*", ranges "a-b", steps "*/n", lists "a,b,c", "?" for "any")
+//   - "@yearly" / "@annually" / "@monthly" / "@weekly" / "@daily" / "@hourly"
+//   - "@reboot" (fires once, immediately, when the engine starts)
+//   - "@after <dur>" (fires once, <dur> after the engine starts)
+//
 // TZ example: "Europe/Paris". If omitted, local time.
-func NextRun(expr string, from time.Time) (time.Time, error) {
+//
+// NextRun takes a Clock rather than a bare time.Time so the reference
+// instant it computes from is explicit at every call site, the same way
+// the rest of the engine threads a Clock through instead of calling
+// time.Now() directly. Callers that already hold a Clock (the engine's
+// own e.Clock) pass it straight through; callers that only have an
+// arbitrary instant to schedule from (a past occurrence during catch-up,
+// a future one while listing upcoming fires) wrap it in clock.Fixed.
+func NextRun(expr string, c clock.Clock) (time.Time, error) {
+	from := c.Now()
 	expr = strings.TrimSpace(expr)
 	l := strings.ToLower(expr)
 
+	if l == "@reboot" {
+		return from, nil
+	}
+	if strings.HasPrefix(l, "@after ") {
+		d, err := parseDur(strings.TrimSpace(strings.TrimPrefix(l, "@after ")))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("@after: %v", err)
+		}
+		return from.Add(d), nil
+	}
+	if spec, ok := atShortcuts[l]; ok {
+		return nextCron(from, spec, time.Local)
+	}
+	if strings.HasPrefix(l, "cron ") {
+		rest := strings.TrimSpace(expr[len("cron "):])
+		m := reCronLine.FindStringSubmatch(rest)
+		if m == nil {
+			return time.Time{}, fmt.Errorf(`cron: expected cron "<5 fields>" [TZ]`)
+		}
+		loc := time.Local
+		if m[2] != "" {
+			lo, err := time.LoadLocation(m[2])
+			if err != nil {
+				return time.Time{}, fmt.Errorf("unknown TZ: %s", m[2])
+			}
+			loc = lo
+		}
+		return nextCron(from, m[1], loc)
+	}
+
 	// every 5m
 	if strings.HasPrefix(l, "every ") && (strings.HasSuffix(l, "s") || strings.HasSuffix(l, "m") || strings.HasSuffix(l, "h") || strings.HasSuffix(l, "d")) && !strings.Contains(l, " at ") {
 		d, err := parseDur(strings.TrimPrefix(l, "every "))
@@ -169,16 +683,19 @@ func NextRun(expr string, from time.Time) (time.Time, error) {
 	// at HH:MM [TZ]
 	if strings.HasPrefix(l, "at ") || strings.HasPrefix(l, "every day at ") || strings.HasPrefix(l, "every weekday at ") {
 		weekdayOnly := false
+		var rest string
 		if strings.HasPrefix(l, "every weekday at ") {
 			weekdayOnly = true
-			l = strings.TrimPrefix(l, "every weekday at ")
+			rest = expr[len("every weekday at "):]
 		} else if strings.HasPrefix(l, "every day at ") {
-			l = strings.TrimPrefix(l, "every day at ")
+			rest = expr[len("every day at "):]
 		} else {
-			l = strings.TrimPrefix(l, "at ")
+			rest = expr[len("at "):]
 		}
 
-		parts := strings.Fields(l)
+		// TZ names are case-sensitive (e.g. "Europe/Paris"), so from here
+		// on work off rest, which preserves the original casing.
+		parts := strings.Fields(rest)
 		if len(parts) == 0 {
 			return time.Time{}, errors.New("missing time")
 		}
@@ -227,8 +744,41 @@ func NextRun(expr string, from time.Time) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unsupported expression (MVP): %q", expr)
 }
 
+// IsOneShot reports whether expr is a schedule that fires exactly once
+// ("@reboot" or "@after <dur>"). The engine removes such jobs from its
+// ready set once they've fired instead of rescheduling them.
+func IsOneShot(expr string) bool {
+	l := strings.ToLower(strings.TrimSpace(expr))
+	return l == "@reboot" || strings.HasPrefix(l, "@after ")
+}
+
 func Explain(j dsl.Job) string {
-	l := strings.ToLower(strings.TrimSpace(j.Schedule))
+	return explainSchedule(j) + ", " + explainRuntime(j)
+}
+
+func explainSchedule(j dsl.Job) string {
+	raw := strings.TrimSpace(j.Schedule)
+	l := strings.ToLower(raw)
+	if l == "@reboot" {
+		return "once, when the scheduler starts"
+	}
+	if strings.HasPrefix(l, "@after ") {
+		return "once, " + strings.TrimPrefix(l, "@after ") + " after the scheduler starts"
+	}
+	if desc, ok := atShortcutExplain[l]; ok {
+		return desc
+	}
+	if strings.HasPrefix(l, "cron ") {
+		rest := strings.TrimSpace(raw[len("cron "):])
+		if m := reCronLine.FindStringSubmatch(rest); m != nil {
+			desc := explainCronSpec(m[1])
+			if m[2] != "" {
+				return fmt.Sprintf("cron (%s) in %s", desc, m[2])
+			}
+			return "cron (" + desc + ")"
+		}
+		return "schedule: " + j.Schedule
+	}
 	if strings.HasPrefix(l, "every ") && (strings.HasSuffix(l, "s") || strings.HasSuffix(l, "m") || strings.HasSuffix(l, "h") || strings.HasSuffix(l, "d")) && !strings.Contains(l, " at ") {
 		return fmt.Sprintf("every %s", strings.TrimPrefix(l, "every "))
 	}
@@ -247,6 +797,21 @@ func Explain(j dsl.Job) string {
 	return "schedule: " + j.Schedule
 }
 
+// explainRuntime describes what the job actually runs, for the `crono
+// explain` subcommand.
+func explainRuntime(j dsl.Job) string {
+	switch j.RunKind {
+	case "http":
+		return fmt.Sprintf("via HTTP %s %s (expect %s)", j.HTTPMethod, j.HTTPURL, j.HTTPExpect)
+	case "docker":
+		return fmt.Sprintf("via docker run --rm %s", j.DockerImage)
+	case "script":
+		return fmt.Sprintf("via script %s", j.ScriptPath)
+	default:
+		return fmt.Sprintf("via shell: %s", j.Run)
+	}
+}
+
 func nextAtTime(from time.Time, hhmm string, loc *time.Location, weekdayOnly bool) time.Time {
 	h := parseHH(hhmm)
 	m := parseMM(hhmm)
@@ -295,8 +860,12 @@ func parseHH(hhmm string) int {
 	}
 	var h int
 	fmt.Sscanf(parts[0], "%d", &h)
-	if h < 0 { h = 0 }
-	if h > 23 { h = 23 }
+	if h < 0 {
+		h = 0
+	}
+	if h > 23 {
+		h = 23
+	}
 	return h
 }
 
@@ -307,12 +876,16 @@ func parseMM(hhmm string) int {
 	}
 	var m int
 	fmt.Sscanf(parts[1], "%d", &m)
-	if m < 0 { m = 0 }
-	if m > 59 { m = 59 }
+	if m < 0 {
+		m = 0
+	}
+	if m > 59 {
+		m = 59
+	}
 	return m
 }
 
 // Small helper for PID without importing os directly here (keeps execer isolated).
 func osGetpid() int {
-	return int(uintptr(time.Now().UnixNano()) & 0xffff) + runtime.NumGoroutine()
+	return int(uintptr(time.Now().UnixNano())&0xffff) + runtime.NumGoroutine()
 }
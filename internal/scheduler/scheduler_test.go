@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/crono/internal/clock"
+	"github.com/example/crono/internal/dsl"
+)
+
+func TestOverlapQueuePreservesOrder(t *testing.T) {
+	f, err := os.CreateTemp("", "crono-queue-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	e := NewEngine(&dsl.Program{})
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		j := dsl.Job{
+			Name:     "queued",
+			Overlap:  "queue",
+			QueueMax: 16,
+			Run:      fmt.Sprintf("echo %d >> %s", i, path),
+		}
+		e.dispatch(ctx, j, time.Now())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	var data []byte
+	for {
+		data, _ = os.ReadFile(path)
+		if strings.Count(string(data), "\n") >= 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queue did not drain in time, got %q", data)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := strings.TrimSpace(string(data)); got != "1\n2\n3" {
+		t.Errorf("overlap: queue ran out of order: got %q, want \"1\\n2\\n3\"", got)
+	}
+}
+
+func TestOverlapCancelPrevTerminatesRunningJob(t *testing.T) {
+	e := NewEngine(&dsl.Program{})
+	ctx := context.Background()
+
+	j := dsl.Job{Name: "cancelme", Overlap: "cancel-prev", Run: "sleep 5"}
+	e.dispatch(ctx, j, time.Now())
+
+	time.Sleep(100 * time.Millisecond) // let the first invocation actually start
+
+	start := time.Now()
+	e.dispatch(ctx, j, time.Now()) // should cancel the sleep's context and hand off quickly
+	elapsed := time.Since(start)
+
+	if elapsed > 1*time.Second {
+		t.Fatalf("cancel-prev took %s to hand off; context cancellation should kill the running shell almost immediately, well under the 5s sleep", elapsed)
+	}
+}
+
+// TestNextRunDST covers the two Europe/Paris DST transitions: clocks spring
+// forward an hour in March (02:00 -> 03:00 does not exist) and fall back an
+// hour in October (02:00 -> 01:00 happens twice). For the "every day at"
+// form, time.Date normalizes nonexistent/ambiguous wall times, so NextRun
+// stays on the same calendar day across either transition; the cron form
+// needs an exact field match and skips the nonexistent hour's day.
+func TestNextRunDST(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Europe/Paris tzdata unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			// 2026-03-29 is Europe/Paris's spring-forward day: 02:00 jumps
+			// straight to 03:00, so the nonexistent 02:30 normalizes
+			// forward to 03:30 on the same calendar day.
+			name:     "spring forward: every day at 02:30 normalizes to 03:30",
+			schedule: "every day at 02:30 Europe/Paris",
+			from:     time.Date(2026, 3, 29, 1, 0, 0, 0, paris),
+			want:     time.Date(2026, 3, 29, 3, 30, 0, 0, paris),
+		},
+		{
+			// 2026-10-25 is the fall-back day: 02:00 occurs twice. 02:30
+			// still fires exactly once.
+			name:     "fall back: every day at 02:30 still fires once on the ambiguous day",
+			schedule: "every day at 02:30 Europe/Paris",
+			from:     time.Date(2026, 10, 25, 1, 0, 0, 0, paris),
+			want:     time.Date(2026, 10, 25, 2, 30, 0, 0, paris),
+		},
+		{
+			// The crontab engine requires an exact field match, so it skips
+			// the spring-forward day entirely rather than normalizing.
+			name:     "cron daily at spring-forward boundary skips the nonexistent day",
+			schedule: `cron "0 2 * * *" Europe/Paris`,
+			from:     time.Date(2026, 3, 28, 3, 0, 0, 0, paris),
+			want:     time.Date(2026, 3, 30, 2, 0, 0, 0, paris),
+		},
+		{
+			// The crontab engine walks the field-by-field bump forward
+			// through increasing instants, so on the ambiguous day it
+			// lands on the earlier (CEST) occurrence of 02:00.
+			name:     "cron daily at fall-back boundary fires once, on the pre-transition offset",
+			schedule: `cron "0 2 * * *" Europe/Paris`,
+			from:     time.Date(2026, 10, 24, 3, 0, 0, 0, paris),
+			want:     time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC).In(paris),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NextRun(tc.schedule, clock.Fixed{At: tc.from})
+			if err != nil {
+				t.Fatalf("NextRun(%q, %s): %v", tc.schedule, tc.from, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("NextRun(%q, %s) = %s, want %s", tc.schedule, tc.from, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestEngineFiringSequenceWithFakeClock drives the engine's main loop with
+// a clock.FakeClock and asserts the exact sequence of fires for a mix of
+// "every" and "@after" jobs, with no real sleeping.
+func TestEngineFiringSequenceWithFakeClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := clock.NewFakeClock(start)
+
+	prog := &dsl.Program{Jobs: []dsl.Job{
+		{Name: "every5m", Schedule: "every 5m", Run: "true", RunKind: "sh"},
+		{Name: "afterTen", Schedule: "@after 10m", Run: "true", RunKind: "sh"},
+	}}
+	e := NewEngine(prog)
+	e.Clock = fc
+
+	events := e.Subscribe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	var scheduled []string
+	deadline := time.After(5 * time.Second)
+	collect := func(want int) {
+		for len(scheduled) < want {
+			select {
+			case ev := <-events:
+				if ev.Type == EventScheduled {
+					scheduled = append(scheduled, ev.Job)
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for %d EventScheduled, got %v", want, scheduled)
+			}
+		}
+	}
+
+	// Give the engine's first iteration a moment to register its initial
+	// wait before we start advancing. every5m first fires at t=5m; the
+	// engine reschedules from firedAt+1s, so its second tick is at
+	// t=10m+1s, and afterTen's one-shot @after at exactly t=10m fires just
+	// ahead of it.
+	time.Sleep(50 * time.Millisecond)
+	fc.Advance(5 * time.Minute) // t=5m: every5m
+	collect(1)
+	fc.Advance(5 * time.Minute) // t=10m: afterTen (every5m's next tick is 1s later)
+	collect(2)
+	fc.Advance(1 * time.Second) // t=10m+1s: every5m again
+	collect(3)
+
+	want := []string{"every5m", "afterTen", "every5m"}
+	for i, w := range want {
+		if scheduled[i] != w {
+			t.Errorf("scheduled[%d] = %q, want %q (full sequence: %v)", i, scheduled[i], w, scheduled)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+// Package store persists per-job scheduling state so the engine can survive
+// a restart: what a job's last scheduled fire was, when it last ran, and
+// how that run ended. It backs the `missed:` catch-up policy in the
+// scheduler package.
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a job's last recorded run.
+type Status string
+
+const (
+	StatusUnknown Status = ""
+	StatusRunning Status = "running"
+	StatusSuccess Status = "success"
+	StatusFailure Status = "failure"
+)
+
+// JobState is the bookkeeping kept per job.
+type JobState struct {
+	LastScheduled time.Time `json:"last_scheduled"`
+	LastStarted   time.Time `json:"last_started"`
+	LastFinished  time.Time `json:"last_finished"`
+	LastStatus    Status    `json:"last_status"`
+	Attempt       int       `json:"attempt"`
+
+	// Circuit breaker / manual pause state (see the `pause_after`/`pause_for`
+	// DSL keys and the `crono pause`/`resume` subcommands).
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	PausedUntil         time.Time `json:"paused_until"`
+	ManualPause         bool      `json:"manual_pause"`
+}
+
+// Paused reports whether the job should be skipped at time now, either
+// because it was paused manually or because the circuit breaker tripped.
+func (s JobState) Paused(now time.Time) bool {
+	return s.ManualPause || (!s.PausedUntil.IsZero() && now.Before(s.PausedUntil))
+}
+
+// RunRecord is one completed run kept in a job's bounded history log, used
+// to serve GET /jobs/{name}/history (see internal/api).
+type RunRecord struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Status   Status    `json:"status"`
+}
+
+// maxHistoryPerJob bounds how many RunRecords MarkFinished retains per job,
+// oldest dropped first, so a long-lived job's history doesn't grow without
+// bound.
+const maxHistoryPerJob = 50
+
+func appendHistory(h []RunRecord, rec RunRecord) []RunRecord {
+	h = append(h, rec)
+	if len(h) > maxHistoryPerJob {
+		h = h[len(h)-maxHistoryPerJob:]
+	}
+	return h
+}
+
+func cloneHistory(in []RunRecord) []RunRecord {
+	return append([]RunRecord(nil), in...)
+}
+
+// Store is a pluggable backend for job state. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Load returns a snapshot of all known job state, keyed by job name.
+	Load() (map[string]JobState, error)
+	MarkScheduled(job string, at time.Time) error
+	MarkStarted(job string, at time.Time, attempt int) error
+	// MarkFinished records the outcome of a run and returns the job's
+	// updated consecutive-failure streak (reset to 0 on success).
+	MarkFinished(job string, at time.Time, status Status) (consecutiveFailures int, err error)
+	// SetPausedUntil trips the circuit breaker until the given time.
+	SetPausedUntil(job string, until time.Time) error
+	// SetManualPause pauses the job until explicitly resumed.
+	SetManualPause(job string) error
+	// ClearPause resumes a job, clearing both manual and breaker pauses
+	// and resetting its failure streak.
+	ClearPause(job string) error
+	// History returns job's recorded runs, oldest first, bounded to the
+	// last maxHistoryPerJob.
+	History(job string) ([]RunRecord, error)
+}
+
+// MemStore is an in-memory Store: it satisfies the interface but forgets
+// everything on restart, which is the behavior crono had before persistence
+// existed. It's the default when no --state path is given.
+type MemStore struct {
+	mu      sync.Mutex
+	states  map[string]JobState
+	history map[string][]RunRecord
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{states: map[string]JobState{}, history: map[string][]RunRecord{}}
+}
+
+func (s *MemStore) Load() (map[string]JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneStates(s.states), nil
+}
+
+func (s *MemStore) MarkScheduled(job string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	st.LastScheduled = at
+	s.states[job] = st
+	return nil
+}
+
+func (s *MemStore) MarkStarted(job string, at time.Time, attempt int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	st.LastStarted = at
+	st.LastStatus = StatusRunning
+	st.Attempt = attempt
+	s.states[job] = st
+	return nil
+}
+
+func (s *MemStore) MarkFinished(job string, at time.Time, status Status) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	rec := RunRecord{Started: st.LastStarted, Finished: at, Status: status}
+	st.LastFinished = at
+	st.LastStatus = status
+	st.ConsecutiveFailures = nextFailureStreak(st.ConsecutiveFailures, status)
+	s.states[job] = st
+	s.history[job] = appendHistory(s.history[job], rec)
+	return st.ConsecutiveFailures, nil
+}
+
+func (s *MemStore) SetPausedUntil(job string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	st.PausedUntil = until
+	s.states[job] = st
+	return nil
+}
+
+func (s *MemStore) SetManualPause(job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	st.ManualPause = true
+	s.states[job] = st
+	return nil
+}
+
+func (s *MemStore) ClearPause(job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.states[job]
+	st.ManualPause = false
+	st.PausedUntil = time.Time{}
+	st.ConsecutiveFailures = 0
+	s.states[job] = st
+	return nil
+}
+
+func (s *MemStore) History(job string) ([]RunRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneHistory(s.history[job]), nil
+}
+
+// JSONStore persists state as a single JSON file at path, rewritten
+// atomically (temp file + rename) on every Mark* call so a crash mid-write
+// never leaves a truncated or corrupt file behind.
+type JSONStore struct {
+	path    string
+	mu      sync.Mutex
+	states  map[string]JobState
+	history map[string][]RunRecord
+}
+
+// jsonStoreFile is the on-disk shape of a JSONStore: per-job state plus
+// each job's bounded run history.
+type jsonStoreFile struct {
+	States  map[string]JobState    `json:"states"`
+	History map[string][]RunRecord `json:"history"`
+}
+
+// parseStoreFile decodes a state file written in the current
+// {"states": ..., "history": ...} shape. It also accepts the flat
+// map[string]JobState shape written before per-job history existed, so
+// upgrading crono against an existing --state file doesn't silently
+// drop its state the first time it's reloaded.
+func parseStoreFile(data []byte) (jsonStoreFile, error) {
+	var f jsonStoreFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, err
+	}
+	if f.States == nil {
+		var legacy map[string]JobState
+		if err := json.Unmarshal(data, &legacy); err == nil {
+			f.States = legacy
+		}
+	}
+	return f, nil
+}
+
+// NewJSONStore opens (or creates) the state file at path.
+func NewJSONStore(path string) (*JSONStore, error) {
+	s := &JSONStore{path: path, states: map[string]JobState{}, history: map[string][]RunRecord{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	if len(data) > 0 {
+		f, err := parseStoreFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("store: invalid state file %s: %w", path, err)
+		}
+		if f.States != nil {
+			s.states = f.States
+		}
+		if f.History != nil {
+			s.history = f.History
+		}
+	}
+	return s, nil
+}
+
+func (s *JSONStore) Load() (map[string]JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	return cloneStates(s.states), nil
+}
+
+func (s *JSONStore) MarkScheduled(job string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	st.LastScheduled = at
+	s.states[job] = st
+	return s.saveLocked()
+}
+
+func (s *JSONStore) MarkStarted(job string, at time.Time, attempt int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	st.LastStarted = at
+	st.LastStatus = StatusRunning
+	st.Attempt = attempt
+	s.states[job] = st
+	return s.saveLocked()
+}
+
+func (s *JSONStore) MarkFinished(job string, at time.Time, status Status) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	rec := RunRecord{Started: st.LastStarted, Finished: at, Status: status}
+	st.LastFinished = at
+	st.LastStatus = status
+	st.ConsecutiveFailures = nextFailureStreak(st.ConsecutiveFailures, status)
+	s.states[job] = st
+	s.history[job] = appendHistory(s.history[job], rec)
+	return st.ConsecutiveFailures, s.saveLocked()
+}
+
+func (s *JSONStore) SetPausedUntil(job string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	st.PausedUntil = until
+	s.states[job] = st
+	return s.saveLocked()
+}
+
+func (s *JSONStore) SetManualPause(job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	st.ManualPause = true
+	s.states[job] = st
+	return s.saveLocked()
+}
+
+func (s *JSONStore) ClearPause(job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	st := s.states[job]
+	st.ManualPause = false
+	st.PausedUntil = time.Time{}
+	st.ConsecutiveFailures = 0
+	s.states[job] = st
+	return s.saveLocked()
+}
+
+func (s *JSONStore) History(job string) ([]RunRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloadLocked()
+	return cloneHistory(s.history[job]), nil
+}
+
+// reloadLocked re-reads the state file from disk into s.states. JSONStore
+// is often shared across processes (e.g. a running `crono run` and a
+// one-shot `crono pause`/`resume` invocation, each with their own
+// *JSONStore), so without this every call would only ever see the state
+// as of NewJSONStore's initial read -- including a pause written by
+// another process -- and the next saveLocked here would clobber it right
+// back out. Call it under s.mu before reading or mutating s.states. A
+// missing/unreadable/corrupt file is treated as "no change since our last
+// read" rather than an error, since saveLocked's atomic rename means
+// readers only ever see a complete old or new file, never a partial one.
+func (s *JSONStore) reloadLocked() {
+	data, err := os.ReadFile(s.path)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	f, err := parseStoreFile(data)
+	if err != nil {
+		return
+	}
+	if f.States != nil {
+		s.states = f.States
+	}
+	if f.History != nil {
+		s.history = f.History
+	}
+}
+
+func nextFailureStreak(current int, status Status) int {
+	if status == StatusFailure {
+		return current + 1
+	}
+	return 0
+}
+
+// saveLocked rewrites the whole state+history file to disk atomically:
+// write to a temp file in the same directory, then rename over path. The
+// rename is what makes crashes safe -- readers only ever see the old or
+// the new file, never a half-written one.
+func (s *JSONStore) saveLocked() error {
+	data, err := json.MarshalIndent(jsonStoreFile{States: s.states, History: s.history}, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, ".crono-state-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+func cloneStates(in map[string]JobState) map[string]JobState {
+	out := make(map[string]JobState, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}